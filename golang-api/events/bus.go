@@ -0,0 +1,88 @@
+// Package events is an in-process pub/sub bus that fans out one event
+// per completed request to every connected WebSocket demo client, so
+// viewers can see "what did APM just capture?" without tailing
+// /app/logs/prod.log.
+package events
+
+import "sync"
+
+// Event is the JSON envelope streamed to each subscriber.
+type Event struct {
+	TraceIDHex string `json:"trace_id_hex"`
+	TraceIDDec string `json:"trace_id_dec"`
+	SpanIDHex  string `json:"span_id_hex"`
+	SpanIDDec  string `json:"span_id_dec"`
+	StatusCode int    `json:"status_code"`
+	Scenario   string `json:"scenario"`
+	LatencyMS  int64  `json:"latency_ms"`
+	LogLine    string `json:"log_line"`
+	Timestamp  string `json:"timestamp"`
+}
+
+// subscriberBuffer is how many unread events a slow client is allowed to
+// fall behind by before new events are dropped for it.
+const subscriberBuffer = 32
+
+// Subscriber receives Events published after it subscribes, until it is
+// unsubscribed.
+type Subscriber struct {
+	events chan Event
+}
+
+// Events returns the channel new events are delivered on.
+func (s *Subscriber) Events() <-chan Event {
+	return s.events
+}
+
+// Bus is a fan-out publisher of Events to any number of subscribers.
+type Bus struct {
+	mu          sync.RWMutex
+	subscribers map[*Subscriber]struct{}
+}
+
+// NewBus returns an empty, ready-to-use Bus.
+func NewBus() *Bus {
+	return &Bus{subscribers: make(map[*Subscriber]struct{})}
+}
+
+// Subscribe registers a new Subscriber and returns it. Callers must call
+// Unsubscribe when done to avoid leaking it.
+func (b *Bus) Subscribe() *Subscriber {
+	sub := &Subscriber{events: make(chan Event, subscriberBuffer)}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.subscribers[sub] = struct{}{}
+	return sub
+}
+
+// Unsubscribe removes sub from the bus and closes its channel.
+func (b *Bus) Unsubscribe(sub *Subscriber) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if _, ok := b.subscribers[sub]; ok {
+		delete(b.subscribers, sub)
+		close(sub.events)
+	}
+}
+
+// Publish delivers evt to every current subscriber. A subscriber whose
+// buffer is full has evt dropped rather than blocking the publisher.
+func (b *Bus) Publish(evt Event) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	for sub := range b.subscribers {
+		select {
+		case sub.events <- evt:
+		default:
+		}
+	}
+}
+
+// SubscriberCount reports how many clients are currently connected.
+func (b *Bus) SubscriberCount() int {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	return len(b.subscribers)
+}
@@ -0,0 +1,88 @@
+package events
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	writeWait  = 10 * time.Second
+	pongWait   = 60 * time.Second
+	pingPeriod = (pongWait * 9) / 10
+)
+
+var upgrader = websocket.Upgrader{
+	ReadBufferSize:  1024,
+	WriteBufferSize: 1024,
+	// The sandbox is a local demo surface; any origin is fine.
+	CheckOrigin: func(r *http.Request) bool { return true },
+}
+
+// Handler upgrades to a WebSocket connection and streams every Event
+// published on bus to this client until it disconnects.
+func Handler(bus *Bus, log *logrus.Logger) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			log.WithError(err).Error("events: websocket upgrade failed")
+			return
+		}
+		defer conn.Close()
+
+		sub := bus.Subscribe()
+		defer bus.Unsubscribe(sub)
+
+		conn.SetReadDeadline(time.Now().Add(pongWait))
+		conn.SetPongHandler(func(string) error {
+			conn.SetReadDeadline(time.Now().Add(pongWait))
+			return nil
+		})
+
+		// Discard anything the client sends; we only read to drive the
+		// pong handler and detect disconnects.
+		go func() {
+			for {
+				if _, _, err := conn.ReadMessage(); err != nil {
+					conn.Close()
+					return
+				}
+			}
+		}()
+
+		ticker := time.NewTicker(pingPeriod)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case evt, ok := <-sub.Events():
+				if !ok {
+					return
+				}
+				conn.SetWriteDeadline(time.Now().Add(writeWait))
+				if err := conn.WriteJSON(evt); err != nil {
+					return
+				}
+			case <-ticker.C:
+				conn.SetWriteDeadline(time.Now().Add(writeWait))
+				if err := conn.WriteMessage(websocket.PingMessage, nil); err != nil {
+					return
+				}
+			}
+		}
+	}
+}
+
+// DebugStatusHandler reports how many clients are currently subscribed,
+// for operators checking whether the demo is actually connected.
+func DebugStatusHandler(bus *Bus) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(struct {
+			ActiveSubscribers int `json:"active_subscribers"`
+		}{ActiveSubscribers: bus.SubscriberCount()})
+	}
+}
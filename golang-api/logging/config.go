@@ -0,0 +1,98 @@
+package logging
+
+import (
+	"os"
+	"strconv"
+	"strings"
+)
+
+// Config configures the logger returned by NewFromConfig.
+type Config struct {
+	// Level is the minimum log level: trace, debug, info, warn, error, fatal, panic.
+	Level string
+	// Format selects the output encoding: "json" (default), "text", or "logfmt".
+	Format string
+	// Outputs lists where log lines are written: "stdout" and/or "file".
+	Outputs []string
+	// File configures on-disk rotation when Outputs includes "file".
+	File FileConfig
+	// Sampling, when set, caps repeated same-level log lines per second.
+	Sampling *SamplingConfig
+}
+
+// FileConfig mirrors lumberjack's rotation knobs.
+type FileConfig struct {
+	Path       string
+	MaxSizeMB  int
+	MaxAgeDays int
+	MaxBackups int
+	Compress   bool
+}
+
+// SamplingConfig keeps the first Initial log lines per level per second
+// and then only every Thereafter-th line after that.
+type SamplingConfig struct {
+	Initial    int
+	Thereafter int
+}
+
+// ConfigFromEnv builds a Config from LOG_* environment variables,
+// defaulting to the sandbox's original behavior (JSON to stdout+file at
+// info level) when nothing is set.
+func ConfigFromEnv() Config {
+	cfg := Config{
+		Level:   os.Getenv("LOG_LEVEL"),
+		Format:  os.Getenv("LOG_FORMAT"),
+		Outputs: splitAndTrim(os.Getenv("LOG_OUTPUTS")),
+		File: FileConfig{
+			Path:       os.Getenv("LOG_FILE_PATH"),
+			MaxSizeMB:  envInt("LOG_FILE_MAX_SIZE_MB", 0),
+			MaxAgeDays: envInt("LOG_FILE_MAX_AGE_DAYS", 0),
+			MaxBackups: envInt("LOG_FILE_MAX_BACKUPS", 0),
+			Compress:   envBool("LOG_FILE_COMPRESS"),
+		},
+	}
+
+	if len(cfg.Outputs) == 0 {
+		cfg.Outputs = []string{"stdout", "file"}
+	}
+	if cfg.File.Path == "" {
+		cfg.File.Path = "/app/logs/prod.log"
+	}
+
+	if initial := envInt("LOG_SAMPLE_INITIAL", 0); initial > 0 {
+		cfg.Sampling = &SamplingConfig{
+			Initial:    initial,
+			Thereafter: envInt("LOG_SAMPLE_THEREAFTER", 100),
+		}
+	}
+
+	return cfg
+}
+
+func splitAndTrim(s string) []string {
+	if s == "" {
+		return nil
+	}
+	parts := strings.Split(s, ",")
+	out := make([]string, 0, len(parts))
+	for _, p := range parts {
+		if p = strings.TrimSpace(p); p != "" {
+			out = append(out, p)
+		}
+	}
+	return out
+}
+
+func envInt(key string, def int) int {
+	v, err := strconv.Atoi(os.Getenv(key))
+	if err != nil {
+		return def
+	}
+	return v
+}
+
+func envBool(key string) bool {
+	v, _ := strconv.ParseBool(os.Getenv(key))
+	return v
+}
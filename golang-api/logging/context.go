@@ -0,0 +1,52 @@
+package logging
+
+import (
+	"context"
+
+	"github.com/sirupsen/logrus"
+)
+
+type entryKey struct{}
+type requestIDKey struct{}
+
+var defaultLogger = &Logger{Logger: logrus.New()}
+
+// SetDefault replaces the fallback logger FromContext uses when a
+// context hasn't gone through Middleware (startup logging, tests, etc).
+func SetDefault(l *Logger) {
+	defaultLogger = l
+}
+
+// WithFields annotates ctx's logger with fields and returns a context
+// carrying the result, so later FromContext(ctx) calls in this request
+// pick up the fields without rebuilding them each time.
+func WithFields(ctx context.Context, fields logrus.Fields) context.Context {
+	entry := FromContext(ctx).WithFields(fields)
+	return context.WithValue(ctx, entryKey{}, entry)
+}
+
+// FromContext returns the request-scoped logger attached to ctx by
+// Middleware, or an entry on the default logger if none was attached.
+// Either way the returned entry carries ctx itself (via WithContext), so
+// trace-correlation hooks (DDContextLogHook, OTelLogHook) registered in
+// tracing.AddLogHooks can read the span that's live on ctx at call time -
+// which may postdate Middleware, since spans start inside the handler.
+func FromContext(ctx context.Context) *logrus.Entry {
+	if entry, ok := ctx.Value(entryKey{}).(*logrus.Entry); ok {
+		return entry.WithContext(ctx)
+	}
+	return logrus.NewEntry(defaultLogger.Logger).WithContext(ctx)
+}
+
+// WithRequestID attaches requestID to ctx for later retrieval via
+// RequestIDFromContext, independent of the logger entry.
+func WithRequestID(ctx context.Context, requestID string) context.Context {
+	return context.WithValue(ctx, requestIDKey{}, requestID)
+}
+
+// RequestIDFromContext returns the request ID Middleware generated (or
+// forwarded from an X-Request-ID header), or "" if none is set.
+func RequestIDFromContext(ctx context.Context) string {
+	id, _ := ctx.Value(requestIDKey{}).(string)
+	return id
+}
@@ -0,0 +1,31 @@
+package logging
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+
+	"github.com/sirupsen/logrus"
+)
+
+const logfmtTimeFormat = "2006-01-02T15:04:05.000Z07:00"
+
+// logfmtFormatter renders entries as space-separated key=value pairs,
+// for shippers that expect logfmt rather than JSON.
+type logfmtFormatter struct{}
+
+func (f *logfmtFormatter) Format(entry *logrus.Entry) ([]byte, error) {
+	keys := make([]string, 0, len(entry.Data))
+	for k := range entry.Data {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "time=%q level=%s msg=%q", entry.Time.Format(logfmtTimeFormat), entry.Level, entry.Message)
+	for _, k := range keys {
+		fmt.Fprintf(&buf, " %s=%v", k, entry.Data[k])
+	}
+	buf.WriteByte('\n')
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,112 @@
+// Package logging is the sandbox's structured logging layer: a leveled,
+// rotating logrus logger configured from a Config, plus context helpers
+// so handlers read/write a request-scoped logger instead of rebuilding
+// WithFields in every branch.
+package logging
+
+import (
+	"io"
+	"os"
+
+	"github.com/sirupsen/logrus"
+	"gopkg.in/natefinch/lumberjack.v2"
+)
+
+// Logger wraps logrus.Logger so call sites keep the familiar
+// WithFields/Info/Error API.
+type Logger struct {
+	*logrus.Logger
+
+	closers []io.Closer
+}
+
+// NewFromConfig builds a Logger from cfg: formatter, level, output
+// targets (with file rotation), and sampling.
+func NewFromConfig(cfg Config) (*Logger, error) {
+	base := logrus.New()
+
+	level, err := logrus.ParseLevel(orDefault(cfg.Level, "info"))
+	if err != nil {
+		return nil, err
+	}
+	base.SetLevel(level)
+
+	formatter := formatterFor(cfg.Format)
+	if cfg.Sampling != nil {
+		formatter = newSamplingFormatter(formatter, *cfg.Sampling)
+	}
+	base.SetFormatter(formatter)
+
+	writers, closers := writersFor(cfg)
+	base.SetOutput(io.MultiWriter(writers...))
+
+	return &Logger{Logger: base, closers: closers}, nil
+}
+
+// Close flushes and closes any rotating file writers. It deliberately
+// leaves os.Stdout alone (closing it would break any later log line,
+// including the error log line a failed Close would itself try to
+// write). Call it after the server has stopped accepting new work, so
+// the last few log lines of a graceful shutdown aren't lost.
+func (l *Logger) Close() error {
+	var firstErr error
+	for _, c := range l.closers {
+		if err := c.Close(); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	return firstErr
+}
+
+// writersFor returns the writers cfg.Outputs selects, plus the subset of
+// those that are file-backed and should be closed on shutdown. stdout is
+// intentionally never included in closers.
+func writersFor(cfg Config) ([]io.Writer, []io.Closer) {
+	writers := make([]io.Writer, 0, len(cfg.Outputs))
+	var closers []io.Closer
+	for _, out := range cfg.Outputs {
+		switch out {
+		case "stdout":
+			writers = append(writers, os.Stdout)
+		case "file":
+			file := &lumberjack.Logger{
+				Filename:   cfg.File.Path,
+				MaxSize:    orDefaultInt(cfg.File.MaxSizeMB, 100),
+				MaxAge:     orDefaultInt(cfg.File.MaxAgeDays, 28),
+				MaxBackups: orDefaultInt(cfg.File.MaxBackups, 3),
+				Compress:   cfg.File.Compress,
+			}
+			writers = append(writers, file)
+			closers = append(closers, file)
+		}
+	}
+	if len(writers) == 0 {
+		writers = append(writers, os.Stdout)
+	}
+	return writers, closers
+}
+
+func formatterFor(format string) logrus.Formatter {
+	switch format {
+	case "text":
+		return &logrus.TextFormatter{}
+	case "logfmt":
+		return &logfmtFormatter{}
+	default:
+		return &logrus.JSONFormatter{}
+	}
+}
+
+func orDefault(v, def string) string {
+	if v == "" {
+		return def
+	}
+	return v
+}
+
+func orDefaultInt(v, def int) int {
+	if v == 0 {
+		return def
+	}
+	return v
+}
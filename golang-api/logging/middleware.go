@@ -0,0 +1,52 @@
+package logging
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/sirupsen/logrus"
+
+	"github.com/petems/minikube-apm-nginx-sandbox/golang-api/tracing"
+)
+
+// Middleware attaches a request-scoped logger to the request context,
+// pre-populated with the request ID, trace/span IDs, route template, and
+// remote address, so handlers can just call FromContext(r.Context()).
+func Middleware(base *Logger) mux.MiddlewareFunc {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			requestID := r.Header.Get("X-Request-ID")
+			if requestID == "" {
+				requestID = NewRequestID()
+			}
+
+			fields := logrus.Fields{
+				"request_id":  requestID,
+				"remote_addr": r.RemoteAddr,
+				"method":      r.Method,
+				"url":         r.URL.String(),
+				"user_agent":  r.UserAgent(),
+			}
+			if route := mux.CurrentRoute(r); route != nil {
+				if tmpl, err := route.GetPathTemplate(); err == nil {
+					fields["route"] = tmpl
+				}
+			}
+			if sc, ok := tracing.SpanContextFromContext(r.Context()); ok {
+				fields["trace_id_hex"] = sc.TraceIDHex
+				fields["trace_id_dec"] = sc.TraceIDDec
+				fields["span_id_hex"] = sc.SpanIDHex
+				fields["span_id_dec"] = sc.SpanIDDec
+			}
+
+			entry := logrus.NewEntry(base.Logger).WithFields(fields)
+			ctx := WithRequestID(r.Context(), requestID)
+			next.ServeHTTP(w, r.WithContext(entryContext(ctx, entry)))
+		})
+	}
+}
+
+func entryContext(ctx context.Context, entry *logrus.Entry) context.Context {
+	return context.WithValue(ctx, entryKey{}, entry)
+}
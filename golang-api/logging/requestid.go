@@ -0,0 +1,11 @@
+package logging
+
+import (
+	"fmt"
+	"time"
+)
+
+// NewRequestID mints a request ID in the sandbox's existing req_<unixnano> form.
+func NewRequestID() string {
+	return fmt.Sprintf("req_%d", time.Now().UnixNano())
+}
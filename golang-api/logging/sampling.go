@@ -0,0 +1,55 @@
+package logging
+
+import (
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// samplingFormatter wraps another formatter and drops entries once a
+// level has logged more than SamplingConfig.Initial lines within the
+// current second, keeping only every Thereafter-th line after that. It's
+// implemented as a formatter (not a hook) because logrus hooks can
+// observe an entry but can't suppress its output.
+type samplingFormatter struct {
+	inner logrus.Formatter
+	cfg   SamplingConfig
+
+	mu     sync.Mutex
+	window time.Time
+	counts map[logrus.Level]int
+}
+
+func newSamplingFormatter(inner logrus.Formatter, cfg SamplingConfig) *samplingFormatter {
+	return &samplingFormatter{inner: inner, cfg: cfg, counts: make(map[logrus.Level]int)}
+}
+
+func (f *samplingFormatter) Format(entry *logrus.Entry) ([]byte, error) {
+	if f.shouldDrop(entry) {
+		return nil, nil
+	}
+	return f.inner.Format(entry)
+}
+
+func (f *samplingFormatter) shouldDrop(entry *logrus.Entry) bool {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	window := entry.Time.Truncate(time.Second)
+	if !window.Equal(f.window) {
+		f.window = window
+		f.counts = make(map[logrus.Level]int)
+	}
+
+	f.counts[entry.Level]++
+	count := f.counts[entry.Level]
+
+	if count <= f.cfg.Initial {
+		return false
+	}
+	if f.cfg.Thereafter <= 0 {
+		return true
+	}
+	return (count-f.cfg.Initial)%f.cfg.Thereafter != 0
+}
@@ -0,0 +1,89 @@
+package logging
+
+import (
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+type countingFormatter struct{ calls int }
+
+func (f *countingFormatter) Format(entry *logrus.Entry) ([]byte, error) {
+	f.calls++
+	return []byte("line"), nil
+}
+
+func entryAt(t time.Time, level logrus.Level) *logrus.Entry {
+	return &logrus.Entry{Logger: logrus.New(), Time: t, Level: level}
+}
+
+func TestSamplingFormatterKeepsInitialLines(t *testing.T) {
+	inner := &countingFormatter{}
+	f := newSamplingFormatter(inner, SamplingConfig{Initial: 2, Thereafter: 10})
+	now := time.Now()
+
+	for i := 0; i < 2; i++ {
+		if _, err := f.Format(entryAt(now, logrus.InfoLevel)); err != nil {
+			t.Fatalf("Format: %v", err)
+		}
+	}
+	if inner.calls != 2 {
+		t.Fatalf("got %d formatted lines within Initial, want 2", inner.calls)
+	}
+}
+
+func TestSamplingFormatterDropsAfterInitialExceptEveryNth(t *testing.T) {
+	inner := &countingFormatter{}
+	f := newSamplingFormatter(inner, SamplingConfig{Initial: 1, Thereafter: 3})
+	now := time.Now()
+
+	var formatted int
+	for i := 0; i < 10; i++ {
+		out, err := f.Format(entryAt(now, logrus.InfoLevel))
+		if err != nil {
+			t.Fatalf("Format: %v", err)
+		}
+		if out != nil {
+			formatted++
+		}
+	}
+	// Line 1 (Initial) plus lines 4, 7, 10 (every Thereafter-th after that).
+	if want := 4; formatted != want {
+		t.Fatalf("got %d formatted lines, want %d", formatted, want)
+	}
+}
+
+func TestSamplingFormatterThereafterZeroDropsEverythingPastInitial(t *testing.T) {
+	inner := &countingFormatter{}
+	f := newSamplingFormatter(inner, SamplingConfig{Initial: 1, Thereafter: 0})
+	now := time.Now()
+
+	for i := 0; i < 5; i++ {
+		if _, err := f.Format(entryAt(now, logrus.InfoLevel)); err != nil {
+			t.Fatalf("Format: %v", err)
+		}
+	}
+	if inner.calls != 1 {
+		t.Fatalf("got %d formatted lines, want 1 (Thereafter=0 should drop everything past Initial)", inner.calls)
+	}
+}
+
+func TestSamplingFormatterResetsPerSecondWindowAndLevel(t *testing.T) {
+	inner := &countingFormatter{}
+	f := newSamplingFormatter(inner, SamplingConfig{Initial: 1, Thereafter: 10})
+	now := time.Now().Truncate(time.Second)
+
+	if _, err := f.Format(entryAt(now, logrus.InfoLevel)); err != nil {
+		t.Fatalf("Format: %v", err)
+	}
+	if _, err := f.Format(entryAt(now.Add(time.Second), logrus.InfoLevel)); err != nil {
+		t.Fatalf("Format: %v", err)
+	}
+	if _, err := f.Format(entryAt(now.Add(time.Second), logrus.ErrorLevel)); err != nil {
+		t.Fatalf("Format: %v", err)
+	}
+	if inner.calls != 3 {
+		t.Fatalf("got %d formatted lines, want 3 (new second and new level should each get their own Initial budget)", inner.calls)
+	}
+}
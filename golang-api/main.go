@@ -1,19 +1,55 @@
 package main
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
-	"math/rand"
 	"net/http"
+	"os"
+	"os/signal"
+	"strconv"
+	"sync/atomic"
+	"syscall"
 	"time"
 
 	"github.com/sirupsen/logrus"
-	muxtrace "gopkg.in/DataDog/dd-trace-go.v1/contrib/gorilla/mux"
+	"golang.org/x/sync/errgroup"
 	"gopkg.in/DataDog/dd-trace-go.v1/ddtrace/ext"
-	"gopkg.in/DataDog/dd-trace-go.v1/ddtrace/tracer"
+
+	"github.com/petems/minikube-apm-nginx-sandbox/golang-api/events"
+	"github.com/petems/minikube-apm-nginx-sandbox/golang-api/logging"
+	"github.com/petems/minikube-apm-nginx-sandbox/golang-api/metrics"
+	"github.com/petems/minikube-apm-nginx-sandbox/golang-api/randpool"
+	"github.com/petems/minikube-apm-nginx-sandbox/golang-api/scenarios"
+	"github.com/petems/minikube-apm-nginx-sandbox/golang-api/tracing"
 )
 
-var log = NewLogger("/app/logs/prod.log")
+// defaultShutdownGracePeriod is how long the server waits for in-flight
+// requests to drain before forcing the shutdown, unless overridden by
+// SHUTDOWN_GRACE_PERIOD_SECONDS.
+const defaultShutdownGracePeriod = 15 * time.Second
+
+// ready reports whether the server should be considered ready to take
+// traffic. It's flipped false as soon as a shutdown signal arrives, so
+// /readyz fails before connections actually start draining.
+var ready atomic.Bool
+
+var (
+	tracerBackend = tracing.BackendFromEnv()
+	trc           = tracing.Init(tracerBackend)
+	appLogger     = newAppLogger()
+	eventsBus     = events.NewBus()
+)
+
+func newAppLogger() *logging.Logger {
+	l, err := logging.NewFromConfig(logging.ConfigFromEnv())
+	if err != nil {
+		panic(fmt.Sprintf("logging: invalid config: %v", err))
+	}
+	tracing.AddLogHooks(l.Logger, tracerBackend)
+	logging.SetDefault(l)
+	return l
+}
 
 // ErrorResponse represents a structured error response
 type ErrorResponse struct {
@@ -50,46 +86,42 @@ var errorScenarios = []ErrorScenario{
 }
 
 func randomStatusHandler(w http.ResponseWriter, r *http.Request) {
+	start := time.Now()
 	rctx := r.Context()
-	span, sctx := tracer.StartSpanFromContext(rctx, "http.request")
-	defer span.Finish()
+	span, sctx := trc.StartSpanFromContext(rctx, "http.request")
+
+	// evt is filled in by whichever branch below runs, then published to
+	// the events bus once the span is finished.
+	var evt events.Event
+	defer func() {
+		span.Finish()
+		sc := span.Context()
+		evt.TraceIDHex, evt.TraceIDDec = sc.TraceIDHex, sc.TraceIDDec
+		evt.SpanIDHex, evt.SpanIDDec = sc.SpanIDHex, sc.SpanIDDec
+		evt.LatencyMS = time.Since(start).Milliseconds()
+		evt.Timestamp = time.Now().UTC().Format(time.RFC3339)
+		eventsBus.Publish(evt)
+	}()
 
 	// Generate unique request ID for tracing
-	requestID := fmt.Sprintf("req_%d", time.Now().UnixNano())
+	requestID := logging.RequestIDFromContext(rctx)
 	span.SetTag("request.id", requestID)
 	span.SetTag("http.method", r.Method)
 	span.SetTag("http.url", r.URL.String())
 
-	// Extract trace and span IDs for enhanced logging visibility
-	spanContext := span.Context()
-	traceID := spanContext.TraceID()
-	spanID := spanContext.SpanID()
-
-	loge := log.
-		WithContext(sctx).
-		WithFields(logrus.Fields{
-			"url":        r.URL.String(),
-			"method":     r.Method,
-			"remote_addr": r.RemoteAddr,
-			"request_id": requestID,
-			"user_agent": r.UserAgent(),
-			// Add both decimal and hex formats for easier correlation with nginx logs
-			"trace_id_dec": traceID,
-			"trace_id_hex": fmt.Sprintf("%016x", traceID),
-			"span_id_dec":  spanID,
-			"span_id_hex":  fmt.Sprintf("%016x", spanID),
-		})
+	loge := logging.FromContext(sctx)
 
 	// Set content type for JSON responses
 	w.Header().Set("Content-Type", "application/json")
 
 	// Simulate different outcomes: 50% success, 30% client error, 20% server error
-	rand.Seed(time.Now().UnixNano())
-	outcome := rand.Float64()
-	
+	rng := randpool.Get()
+	defer randpool.Put(rng)
+	outcome := rng.Float64()
+
 	timestamp := time.Now().UTC().Format(time.RFC3339)
 
-	if outcome < 0.5 {
+	if outcome < successProbability {
 		// Success case
 		span.SetTag("http.status_code", http.StatusOK)
 		span.SetTag(ext.HTTPCode, "200")
@@ -105,13 +137,17 @@ func randomStatusHandler(w http.ResponseWriter, r *http.Request) {
 			"status_code": http.StatusOK,
 			"response":    "success",
 		}).Info("Request processed successfully")
-		
+		evt.StatusCode = http.StatusOK
+		evt.Scenario = "success"
+		evt.LogLine = "Request processed successfully"
+		metrics.ObserveScenario(r.URL.Path, r.Method, http.StatusOK, "")
+
 		w.WriteHeader(http.StatusOK)
 		json.NewEncoder(w).Encode(response)
-		
-	} else if outcome < 0.8 {
+
+	} else if outcome < clientErrorProbability {
 		// Client error (400)
-		scenario := errorScenarios[rand.Intn(3)] // First 3 are 400 errors
+		scenario := errorScenarios[rng.Intn(3)] // First 3 are 400 errors
 		
 		span.SetTag("http.status_code", scenario.StatusCode)
 		span.SetTag(ext.HTTPCode, fmt.Sprintf("%d", scenario.StatusCode))
@@ -135,13 +171,17 @@ func randomStatusHandler(w http.ResponseWriter, r *http.Request) {
 			"error_reason": scenario.Reason,
 			"error_type":   "client_error",
 		}).Error("Client error occurred")
-		
+		evt.StatusCode = scenario.StatusCode
+		evt.Scenario = scenario.ErrorCode
+		evt.LogLine = "Client error occurred"
+		metrics.ObserveScenario(r.URL.Path, r.Method, scenario.StatusCode, scenario.ErrorCode)
+
 		w.WriteHeader(scenario.StatusCode)
 		json.NewEncoder(w).Encode(response)
-		
+
 	} else {
 		// Server error (500)
-		scenario := errorScenarios[3+rand.Intn(3)] // Last 3 are 500 errors
+		scenario := errorScenarios[3+rng.Intn(3)] // Last 3 are 500 errors
 		
 		span.SetTag("http.status_code", scenario.StatusCode)
 		span.SetTag(ext.HTTPCode, fmt.Sprintf("%d", scenario.StatusCode))
@@ -165,33 +205,136 @@ func randomStatusHandler(w http.ResponseWriter, r *http.Request) {
 			"error_reason": scenario.Reason,
 			"error_type":   "server_error",
 		}).Error("Server error occurred")
-		
+		evt.StatusCode = scenario.StatusCode
+		evt.Scenario = scenario.ErrorCode
+		evt.LogLine = "Server error occurred"
+		metrics.ObserveScenario(r.URL.Path, r.Method, scenario.StatusCode, scenario.ErrorCode)
+
 		w.WriteHeader(scenario.StatusCode)
 		json.NewEncoder(w).Encode(response)
 	}
 }
 
-func healthHandler(w http.ResponseWriter, r *http.Request) {
+// livezHandler always returns 200 while the process is up, so Kubernetes
+// only restarts the pod on an actual crash/hang.
+func livezHandler(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+
+	json.NewEncoder(w).Encode(SuccessResponse{
+		Status:    "alive",
+		Message:   "Process is up",
+		Timestamp: time.Now().UTC().Format(time.RFC3339),
+	})
+}
+
+// readyzHandler returns 503 once shutdown begins, so Kubernetes stops
+// routing new traffic to this pod while it drains.
+func readyzHandler(w http.ResponseWriter, r *http.Request) {
 	w.Header().Set("Content-Type", "application/json")
+
+	if !ready.Load() {
+		w.WriteHeader(http.StatusServiceUnavailable)
+		json.NewEncoder(w).Encode(SuccessResponse{
+			Status:    "not_ready",
+			Message:   "Server is shutting down",
+			Timestamp: time.Now().UTC().Format(time.RFC3339),
+		})
+		return
+	}
+
 	w.WriteHeader(http.StatusOK)
-	
-	response := SuccessResponse{
-		Status:    "healthy",
+	json.NewEncoder(w).Encode(SuccessResponse{
+		Status:    "ready",
 		Message:   "Service is healthy",
 		Timestamp: time.Now().UTC().Format(time.RFC3339),
+	})
+}
+
+func shutdownGracePeriod() time.Duration {
+	secs, err := strconv.Atoi(os.Getenv("SHUTDOWN_GRACE_PERIOD_SECONDS"))
+	if err != nil || secs <= 0 {
+		return defaultShutdownGracePeriod
 	}
-	
-	json.NewEncoder(w).Encode(response)
+	return time.Duration(secs) * time.Second
 }
 
 func main() {
-	tracer.Start()
-	defer tracer.Stop()
+	ready.Store(true)
+
+	r := trc.NewRouter()
+	r.Use(logging.Middleware(appLogger))
+	r.Use(metrics.Middleware)
+
+	var engine *scenarios.Engine
+	if scenariosFile := os.Getenv("SCENARIOS_FILE"); scenariosFile != "" {
+		var err error
+		engine, err = scenarios.NewEngine(scenariosFile)
+		if err != nil {
+			appLogger.Fatal(fmt.Sprintf("scenarios: failed to load %s: %v", scenariosFile, err))
+		}
+		scenarios.RegisterRoutes(r, trc, eventsBus, engine)
+	} else {
+		r.HandleFunc("/", randomStatusHandler)
+	}
+	r.HandleFunc("/livez", livezHandler)
+	r.HandleFunc("/readyz", readyzHandler)
+	r.HandleFunc("/ws/events", events.Handler(eventsBus, appLogger.Logger))
+	r.HandleFunc("/debug/status", events.DebugStatusHandler(eventsBus))
+	r.Handle("/metrics", metrics.Handler())
+
+	server := &http.Server{Addr: ":8080", Handler: r}
+
+	// shutdownCtx is canceled as soon as a shutdown signal arrives, ahead
+	// of the drain timeout below, so goroutines with no request to finish
+	// (like the scenario file watcher) stop immediately instead of
+	// leaking past server.Shutdown.
+	shutdownCtx, cancelShutdown := context.WithCancel(context.Background())
+	defer cancelShutdown()
 
-	r := muxtrace.NewRouter()
-	r.HandleFunc("/", randomStatusHandler)
-	r.HandleFunc("/health", healthHandler)
+	g, gctx := errgroup.WithContext(shutdownCtx)
 
-	log.Println("Started")
-	log.Fatal(http.ListenAndServe(":8080", r))
+	g.Go(func() error {
+		appLogger.Println("Started")
+		if err := server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+			return err
+		}
+		return nil
+	})
+
+	if engine != nil {
+		g.Go(func() error {
+			scenarios.Watch(gctx, engine, appLogger.Logger)
+			return nil
+		})
+	}
+
+	g.Go(func() error {
+		sigCh := make(chan os.Signal, 1)
+		signal.Notify(sigCh, syscall.SIGINT, syscall.SIGTERM)
+		defer signal.Stop(sigCh)
+
+		select {
+		case sig := <-sigCh:
+			appLogger.WithField("signal", sig.String()).Println("Shutdown signal received, draining connections")
+		case <-gctx.Done():
+			return nil
+		}
+
+		ready.Store(false)
+		cancelShutdown()
+
+		drainCtx, cancel := context.WithTimeout(context.Background(), shutdownGracePeriod())
+		defer cancel()
+		return server.Shutdown(drainCtx)
+	})
+
+	if err := g.Wait(); err != nil {
+		appLogger.WithError(err).Error("server exited with error")
+	}
+
+	trc.Stop()
+	if err := appLogger.Close(); err != nil {
+		appLogger.WithError(err).Error("failed to close log file")
+	}
 }
@@ -0,0 +1,49 @@
+// Package metrics exposes Prometheus RED metrics (rate, errors,
+// duration) for the sandbox, as a second signal alongside APM traces so
+// users can verify Datadog's numbers against a Prometheus scrape in
+// minikube.
+package metrics
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+var (
+	requestsTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "sandbox_http_requests_total",
+		Help: "Total HTTP requests, labeled by route, method, and status code.",
+	}, []string{"route", "method", "status_code"})
+
+	requestDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "sandbox_http_request_duration_seconds",
+		Help:    "HTTP request latency in seconds, labeled by route, method, and status code.",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"route", "method", "status_code"})
+
+	requestsInFlight = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "sandbox_http_requests_in_flight",
+		Help: "Current number of HTTP requests being served.",
+	})
+
+	scenarioTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "sandbox_scenario_total",
+		Help: "Total requests per simulated scenario outcome, labeled by route, method, status code, and scenario error code.",
+	}, []string{"route", "method", "status_code", "error_code"})
+)
+
+// ObserveScenario records the simulated outcome a handler chose, so the
+// scenario that produced a given status code can be seen alongside the
+// generic RED metrics above. errorCode is "" for successful outcomes.
+func ObserveScenario(route, method string, statusCode int, errorCode string) {
+	scenarioTotal.WithLabelValues(route, method, strconv.Itoa(statusCode), errorCode).Inc()
+}
+
+// Handler exposes the registered metrics for Prometheus to scrape.
+func Handler() http.Handler {
+	return promhttp.Handler()
+}
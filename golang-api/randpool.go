@@ -0,0 +1,10 @@
+package main
+
+// Probability split for the simulated outcomes in randomStatusHandler:
+// successProbability of requests succeed, the next
+// (clientErrorProbability - successProbability) are client errors, and
+// the remainder are server errors.
+const (
+	successProbability     = 0.5
+	clientErrorProbability = 0.8
+)
@@ -0,0 +1,45 @@
+// Package randpool hands out per-goroutine *rand.Rand values backed by a
+// sync.Pool of crypto/rand-seeded sources, instead of reseeding and using
+// the global math/rand source on every call - which both defeats
+// randomness under load (same-nanosecond calls produce the same seed)
+// and serializes on the global source's mutex. Any caller that samples
+// randomness on the request path (main's randomStatusHandler, the
+// scenarios engine's Pick/Latency) should draw from here rather than
+// calling math/rand's package-level functions directly.
+package randpool
+
+import (
+	cryptorand "crypto/rand"
+	"encoding/binary"
+	"math/rand"
+	"sync"
+)
+
+var pool = sync.Pool{
+	New: func() interface{} {
+		return rand.New(rand.NewSource(newSeed()))
+	},
+}
+
+// Get returns a *rand.Rand for exclusive use until it's returned via Put.
+func Get() *rand.Rand {
+	return pool.Get().(*rand.Rand)
+}
+
+// Put returns rng to the pool for reuse.
+func Put(rng *rand.Rand) {
+	pool.Put(rng)
+}
+
+// newSeed draws a seed from crypto/rand so pool entries created
+// concurrently at startup don't collide the way time.Now().UnixNano()
+// seeds can.
+func newSeed() int64 {
+	var b [8]byte
+	if _, err := cryptorand.Read(b[:]); err != nil {
+		// crypto/rand failing is effectively unrecoverable entropy
+		// starvation; a fixed fallback still keeps the caller working.
+		return 1
+	}
+	return int64(binary.LittleEndian.Uint64(b[:]))
+}
@@ -0,0 +1,116 @@
+package scenarios
+
+import (
+	"fmt"
+	"math"
+	"sync/atomic"
+	"time"
+
+	"github.com/petems/minikube-apm-nginx-sandbox/golang-api/randpool"
+)
+
+// Engine serves scenario picks and latency sampling from a Config that
+// can be swapped out at runtime (see Watch) without restarting the
+// process.
+type Engine struct {
+	path string
+	cfg  atomic.Pointer[Config]
+}
+
+// NewEngine loads path and returns an Engine ready to serve it.
+func NewEngine(path string) (*Engine, error) {
+	cfg, err := Load(path)
+	if err != nil {
+		return nil, err
+	}
+	e := &Engine{path: path}
+	e.cfg.Store(cfg)
+	return e, nil
+}
+
+// Reload re-reads the engine's backing file and atomically swaps in the
+// new config. Callers already serving requests are unaffected until the
+// swap completes, and never see a partially-loaded config.
+func (e *Engine) Reload() error {
+	cfg, err := Load(e.path)
+	if err != nil {
+		return err
+	}
+	e.cfg.Store(cfg)
+	return nil
+}
+
+// Config returns the currently active config.
+func (e *Engine) Config() *Config {
+	return e.cfg.Load()
+}
+
+// RouteFor returns the RouteConfig matching path and method, if any.
+func (e *Engine) RouteFor(path, method string) (RouteConfig, bool) {
+	for _, route := range e.cfg.Load().Routes {
+		if route.Path == path && (route.Method == "" || route.Method == method) {
+			return route, true
+		}
+	}
+	return RouteConfig{}, false
+}
+
+// Pick weighted-randomly selects one Scenario from route's scenarios.
+func Pick(route RouteConfig) (Scenario, error) {
+	if len(route.Scenarios) == 0 {
+		return Scenario{}, fmt.Errorf("scenarios: route %s has no scenarios configured", route.Path)
+	}
+
+	rng := randpool.Get()
+	defer randpool.Put(rng)
+
+	var total float64
+	for _, s := range route.Scenarios {
+		total += s.Weight
+	}
+	if total <= 0 {
+		return route.Scenarios[rng.Intn(len(route.Scenarios))], nil
+	}
+
+	r := rng.Float64() * total
+	var cumulative float64
+	for _, s := range route.Scenarios {
+		cumulative += s.Weight
+		if r < cumulative {
+			return s, nil
+		}
+	}
+	return route.Scenarios[len(route.Scenarios)-1], nil
+}
+
+// Latency samples a delay from cfg's distribution. The zero value
+// (Distribution == "") means no injected delay.
+func Latency(cfg LatencyConfig) time.Duration {
+	rng := randpool.Get()
+	defer randpool.Put(rng)
+
+	switch cfg.Distribution {
+	case LatencyFixed:
+		return time.Duration(cfg.DurationMS) * time.Millisecond
+	case LatencyUniform:
+		spread := cfg.MaxMS - cfg.MinMS
+		if spread <= 0 {
+			return time.Duration(cfg.MinMS) * time.Millisecond
+		}
+		return time.Duration(cfg.MinMS+rng.Intn(spread)) * time.Millisecond
+	case LatencyNormal:
+		ms := rng.NormFloat64()*cfg.StdDevMS + cfg.MeanMS
+		if ms < 0 {
+			ms = 0
+		}
+		return time.Duration(ms) * time.Millisecond
+	case LatencyExponential:
+		if cfg.RateMS <= 0 {
+			return 0
+		}
+		ms := rng.ExpFloat64() * cfg.RateMS
+		return time.Duration(math.Round(ms)) * time.Millisecond
+	default:
+		return 0
+	}
+}
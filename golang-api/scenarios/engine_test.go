@@ -0,0 +1,102 @@
+package scenarios
+
+import (
+	"testing"
+	"time"
+)
+
+func TestPickHonorsWeights(t *testing.T) {
+	route := RouteConfig{
+		Path: "/widgets",
+		Scenarios: []Scenario{
+			{Name: "ok", Weight: 1},
+			{Name: "never", Weight: 0},
+		},
+	}
+
+	for i := 0; i < 100; i++ {
+		got, err := Pick(route)
+		if err != nil {
+			t.Fatalf("Pick: %v", err)
+		}
+		if got.Name != "ok" {
+			t.Fatalf("Pick returned %q, want %q (weight 0 scenario should never be picked)", got.Name, "ok")
+		}
+	}
+}
+
+func TestPickFallsBackToUniformWhenWeightsAreZero(t *testing.T) {
+	route := RouteConfig{
+		Path: "/widgets",
+		Scenarios: []Scenario{
+			{Name: "a", Weight: 0},
+			{Name: "b", Weight: 0},
+		},
+	}
+
+	seen := make(map[string]bool)
+	for i := 0; i < 100; i++ {
+		got, err := Pick(route)
+		if err != nil {
+			t.Fatalf("Pick: %v", err)
+		}
+		seen[got.Name] = true
+	}
+	if len(seen) != 2 {
+		t.Fatalf("Pick with all-zero weights should still be able to return either scenario, got %v", seen)
+	}
+}
+
+func TestPickNoScenariosConfigured(t *testing.T) {
+	route := RouteConfig{Path: "/empty"}
+	if _, err := Pick(route); err == nil {
+		t.Fatal("Pick with no scenarios configured should return an error")
+	}
+}
+
+func TestLatencyFixed(t *testing.T) {
+	got := Latency(LatencyConfig{Distribution: LatencyFixed, DurationMS: 42})
+	if want := 42 * time.Millisecond; got != want {
+		t.Fatalf("Latency(fixed) = %v, want %v", got, want)
+	}
+}
+
+func TestLatencyUniformWithinBounds(t *testing.T) {
+	cfg := LatencyConfig{Distribution: LatencyUniform, MinMS: 10, MaxMS: 20}
+	for i := 0; i < 100; i++ {
+		got := Latency(cfg)
+		if got < 10*time.Millisecond || got >= 20*time.Millisecond {
+			t.Fatalf("Latency(uniform) = %v, want in [10ms, 20ms)", got)
+		}
+	}
+}
+
+func TestLatencyUniformZeroSpreadReturnsMin(t *testing.T) {
+	got := Latency(LatencyConfig{Distribution: LatencyUniform, MinMS: 10, MaxMS: 10})
+	if want := 10 * time.Millisecond; got != want {
+		t.Fatalf("Latency(uniform, zero spread) = %v, want %v", got, want)
+	}
+}
+
+func TestLatencyNormalNeverNegative(t *testing.T) {
+	cfg := LatencyConfig{Distribution: LatencyNormal, MeanMS: 0, StdDevMS: 50}
+	for i := 0; i < 200; i++ {
+		if got := Latency(cfg); got < 0 {
+			t.Fatalf("Latency(normal) = %v, want >= 0", got)
+		}
+	}
+}
+
+func TestLatencyExponentialZeroRateReturnsNoDelay(t *testing.T) {
+	got := Latency(LatencyConfig{Distribution: LatencyExponential, RateMS: 0})
+	if got != 0 {
+		t.Fatalf("Latency(exponential, rate 0) = %v, want 0", got)
+	}
+}
+
+func TestLatencyUnknownDistributionReturnsNoDelay(t *testing.T) {
+	got := Latency(LatencyConfig{})
+	if got != 0 {
+		t.Fatalf("Latency(zero value) = %v, want 0", got)
+	}
+}
@@ -0,0 +1,171 @@
+package scenarios
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"gopkg.in/DataDog/dd-trace-go.v1/ddtrace/ext"
+
+	"github.com/petems/minikube-apm-nginx-sandbox/golang-api/events"
+	"github.com/petems/minikube-apm-nginx-sandbox/golang-api/logging"
+	"github.com/petems/minikube-apm-nginx-sandbox/golang-api/metrics"
+	"github.com/petems/minikube-apm-nginx-sandbox/golang-api/tracing"
+)
+
+// response is the JSON body written for a scenario pick. It mirrors the
+// shape of main's ErrorResponse/SuccessResponse so scenario-driven and
+// hardcoded routes look identical on the wire.
+type response struct {
+	Status    string `json:"status,omitempty"`
+	Error     string `json:"error,omitempty"`
+	Message   string `json:"message,omitempty"`
+	Code      string `json:"code,omitempty"`
+	Timestamp string `json:"timestamp"`
+	RequestID string `json:"request_id,omitempty"`
+}
+
+// Handler builds the http.HandlerFunc that drives the route registered
+// at path/method. On every request it re-reads engine's current config
+// (so a reload takes effect without re-registering routes), picks a
+// scenario, applies its latency and chaos behavior, and writes its
+// response - all while participating in the sandbox's normal tracing and
+// logging.
+func Handler(trc tracing.Tracer, bus *events.Bus, engine *Engine, path, method string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+		span, sctx := trc.StartSpanFromContext(r.Context(), "http.request")
+
+		var evt events.Event
+		defer func() {
+			span.Finish()
+			sc := span.Context()
+			evt.TraceIDHex, evt.TraceIDDec = sc.TraceIDHex, sc.TraceIDDec
+			evt.SpanIDHex, evt.SpanIDDec = sc.SpanIDHex, sc.SpanIDDec
+			evt.LatencyMS = time.Since(start).Milliseconds()
+			evt.Timestamp = time.Now().UTC().Format(time.RFC3339)
+			bus.Publish(evt)
+		}()
+
+		requestID := logging.RequestIDFromContext(r.Context())
+		span.SetTag("http.method", r.Method)
+		span.SetTag("http.url", r.URL.String())
+
+		loge := logging.FromContext(sctx)
+
+		route, ok := engine.RouteFor(path, method)
+		if !ok {
+			loge.Error("scenarios: route no longer present after reload")
+			evt.StatusCode = http.StatusInternalServerError
+			evt.LogLine = "scenarios: route no longer present after reload"
+			http.Error(w, "scenario route not found", http.StatusInternalServerError)
+			return
+		}
+
+		scenario, err := Pick(route)
+		if err != nil {
+			loge.WithError(err).Error("scenarios: failed to pick scenario")
+			evt.StatusCode = http.StatusInternalServerError
+			evt.LogLine = "scenarios: failed to pick scenario"
+			http.Error(w, "scenario engine misconfigured", http.StatusInternalServerError)
+			return
+		}
+
+		evt.Scenario = scenario.Name
+		evt.StatusCode = scenario.StatusCode
+		span.SetTag("scenario.name", scenario.Name)
+		span.SetTag("http.status_code", scenario.StatusCode)
+		span.SetTag(ext.HTTPCode, scenario.StatusCode)
+		if scenario.StatusCode >= http.StatusBadRequest {
+			span.SetTag(ext.Error, true)
+			span.SetTag("error.code", scenario.ErrorCode)
+		}
+
+		if delay := Latency(scenario.Latency); delay > 0 {
+			span.SetTag("scenario.latency_ms", delay.Milliseconds())
+			time.Sleep(delay)
+		}
+
+		if scenario.Chaos != nil && scenario.Chaos.DropConnection {
+			loge.WithField("scenario", scenario.Name).Warn("scenarios: dropping connection per chaos config")
+			evt.LogLine = "scenarios: dropped connection per chaos config"
+			dropConnection(w)
+			return
+		}
+
+		for k, v := range scenario.Headers {
+			w.Header().Set(k, v)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(scenario.StatusCode)
+
+		body, err := json.Marshal(response{
+			Status:    statusFor(scenario),
+			Error:     scenario.ErrorCode,
+			Message:   scenario.Message,
+			Code:      scenario.Reason,
+			Timestamp: time.Now().UTC().Format(time.RFC3339),
+			RequestID: requestID,
+		})
+		if err != nil {
+			loge.WithError(err).Error("scenarios: failed to marshal response")
+			return
+		}
+
+		loge.WithFields(map[string]interface{}{
+			"scenario":    scenario.Name,
+			"status_code": scenario.StatusCode,
+			"error_code":  scenario.ErrorCode,
+		}).Info("Request processed by scenario engine")
+		evt.LogLine = "Request processed by scenario engine"
+		metrics.ObserveScenario(path, method, scenario.StatusCode, scenario.ErrorCode)
+
+		writeBody(w, body, scenario.Chaos)
+	}
+}
+
+func statusFor(s Scenario) string {
+	if s.StatusCode >= http.StatusBadRequest {
+		return ""
+	}
+	return "success"
+}
+
+// writeBody honors SlowWrite/PartialJSON chaos behaviors when present,
+// otherwise writes body in one shot like a normal handler.
+func writeBody(w http.ResponseWriter, body []byte, chaos *ChaosConfig) {
+	if chaos != nil && chaos.PartialJSON && len(body) > 1 {
+		body = body[:len(body)/2]
+	}
+
+	if chaos == nil || !chaos.SlowWrite {
+		w.Write(body)
+		return
+	}
+
+	flusher, canFlush := w.(http.Flusher)
+	delay := time.Duration(chaos.SlowWriteDelayMS) * time.Millisecond
+	for _, b := range body {
+		w.Write([]byte{b})
+		if canFlush {
+			flusher.Flush()
+		}
+		if delay > 0 {
+			time.Sleep(delay)
+		}
+	}
+}
+
+// dropConnection closes the underlying TCP connection without writing a
+// response, simulating a backend that died mid-request.
+func dropConnection(w http.ResponseWriter) {
+	hijacker, ok := w.(http.Hijacker)
+	if !ok {
+		return
+	}
+	conn, _, err := hijacker.Hijack()
+	if err != nil {
+		return
+	}
+	conn.Close()
+}
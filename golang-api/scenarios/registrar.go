@@ -0,0 +1,19 @@
+package scenarios
+
+import (
+	"github.com/gorilla/mux"
+
+	"github.com/petems/minikube-apm-nginx-sandbox/golang-api/events"
+	"github.com/petems/minikube-apm-nginx-sandbox/golang-api/tracing"
+)
+
+// RegisterRoutes adds one handler per route in engine's config to r, so
+// a single scenarios file can drive "/", "/users/{id}", "/checkout", etc.
+func RegisterRoutes(r *mux.Router, trc tracing.Tracer, bus *events.Bus, engine *Engine) {
+	for _, route := range engine.Config().Routes {
+		rt := r.HandleFunc(route.Path, Handler(trc, bus, engine, route.Path, route.Method))
+		if route.Method != "" {
+			rt.Methods(route.Method)
+		}
+	}
+}
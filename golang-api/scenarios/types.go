@@ -0,0 +1,73 @@
+// Package scenarios loads the sandbox's simulated-outcome behavior from a
+// YAML/JSON file instead of the hardcoded errorScenarios slice, so users
+// can reproduce a specific customer incident against the APM pipeline
+// without recompiling.
+package scenarios
+
+// Config is the root of a scenarios file: one entry per route the engine
+// should drive.
+type Config struct {
+	Routes []RouteConfig `yaml:"routes" json:"routes"`
+}
+
+// RouteConfig describes one HTTP route and the weighted outcomes it can
+// produce.
+type RouteConfig struct {
+	Path      string     `yaml:"path" json:"path"`
+	Method    string     `yaml:"method" json:"method"`
+	Scenarios []Scenario `yaml:"scenarios" json:"scenarios"`
+}
+
+// Scenario is one weighted outcome for a route: a response to write,
+// optional latency before writing it, and optional chaos behavior.
+type Scenario struct {
+	Name       string            `yaml:"name" json:"name"`
+	Weight     float64           `yaml:"weight" json:"weight"`
+	StatusCode int               `yaml:"status_code" json:"status_code"`
+	ErrorCode  string            `yaml:"error_code" json:"error_code"`
+	Message    string            `yaml:"message" json:"message"`
+	Reason     string            `yaml:"reason" json:"reason"`
+	Headers    map[string]string `yaml:"headers" json:"headers"`
+	Latency    LatencyConfig     `yaml:"latency" json:"latency"`
+	Chaos      *ChaosConfig      `yaml:"chaos" json:"chaos"`
+}
+
+// LatencyDistribution selects how Scenario latency is sampled.
+type LatencyDistribution string
+
+const (
+	LatencyFixed       LatencyDistribution = "fixed"
+	LatencyUniform     LatencyDistribution = "uniform"
+	LatencyNormal      LatencyDistribution = "normal"
+	LatencyExponential LatencyDistribution = "exponential"
+)
+
+// LatencyConfig describes the delay injected before a scenario's response
+// is written. The fields that apply depend on Distribution:
+//   - fixed: DurationMS
+//   - uniform: MinMS, MaxMS
+//   - normal: MeanMS, StdDevMS
+//   - exponential: RateMS (mean delay in ms; 1/rate)
+type LatencyConfig struct {
+	Distribution LatencyDistribution `yaml:"distribution" json:"distribution"`
+	DurationMS   int                 `yaml:"duration_ms" json:"duration_ms"`
+	MinMS        int                 `yaml:"min_ms" json:"min_ms"`
+	MaxMS        int                 `yaml:"max_ms" json:"max_ms"`
+	MeanMS       float64             `yaml:"mean_ms" json:"mean_ms"`
+	StdDevMS     float64             `yaml:"stddev_ms" json:"stddev_ms"`
+	RateMS       float64             `yaml:"rate_ms" json:"rate_ms"`
+}
+
+// ChaosConfig describes connection-level misbehavior layered on top of a
+// scenario's normal response, for reproducing transport-level incidents.
+type ChaosConfig struct {
+	// DropConnection closes the connection without writing a response.
+	DropConnection bool `yaml:"drop_connection" json:"drop_connection"`
+	// SlowWrite writes the response body one byte at a time with
+	// SlowWriteDelayMS between bytes.
+	SlowWrite        bool `yaml:"slow_write" json:"slow_write"`
+	SlowWriteDelayMS int  `yaml:"slow_write_delay_ms" json:"slow_write_delay_ms"`
+	// PartialJSON truncates the encoded JSON body before writing it, so
+	// clients see a response that fails to parse.
+	PartialJSON bool `yaml:"partial_json" json:"partial_json"`
+}
@@ -0,0 +1,60 @@
+package scenarios
+
+import (
+	"context"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/sirupsen/logrus"
+)
+
+// Watch reloads engine whenever its backing file changes on disk or the
+// process receives SIGHUP, and logs the outcome of each reload. It runs
+// until ctx is canceled, so the caller ties it to main's shutdown.
+func Watch(ctx context.Context, engine *Engine, log *logrus.Logger) {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		log.WithError(err).Error("scenarios: failed to start file watcher, SIGHUP reload still works")
+	} else {
+		if err := watcher.Add(engine.path); err != nil {
+			log.WithError(err).Error("scenarios: failed to watch scenarios file")
+		}
+		defer watcher.Close()
+	}
+
+	sighup := make(chan os.Signal, 1)
+	signal.Notify(sighup, syscall.SIGHUP)
+	defer signal.Stop(sighup)
+
+	reload := func(reason string) {
+		if err := engine.Reload(); err != nil {
+			log.WithError(err).WithField("reason", reason).Error("scenarios: reload failed, keeping previous config")
+			return
+		}
+		log.WithField("reason", reason).Info("scenarios: reloaded config")
+	}
+
+	var events <-chan fsnotify.Event
+	if watcher != nil {
+		events = watcher.Events
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-sighup:
+			reload("sighup")
+		case event, ok := <-events:
+			if !ok {
+				events = nil
+				continue
+			}
+			if event.Op&(fsnotify.Write|fsnotify.Create) != 0 {
+				reload("file_change")
+			}
+		}
+	}
+}
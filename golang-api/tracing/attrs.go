@@ -0,0 +1,39 @@
+package tracing
+
+import (
+	"encoding/binary"
+	"fmt"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// attributeFor converts the loosely-typed SetTag values used throughout
+// the handler into a typed OTel attribute.KeyValue.
+func attributeFor(key string, value interface{}) attribute.KeyValue {
+	switch v := value.(type) {
+	case string:
+		return attribute.String(key, v)
+	case bool:
+		return attribute.Bool(key, v)
+	case int:
+		return attribute.Int(key, v)
+	case int64:
+		return attribute.Int64(key, v)
+	case float64:
+		return attribute.Float64(key, v)
+	default:
+		return attribute.String(key, fmt.Sprintf("%v", v))
+	}
+}
+
+// traceIDLow64 takes the low 64 bits of a 128-bit OTel trace ID, matching
+// the convention the Datadog/OTel bridge uses when a Datadog-style decimal
+// trace ID is needed for log correlation.
+func traceIDLow64(id trace.TraceID) uint64 {
+	return binary.BigEndian.Uint64(id[8:])
+}
+
+func spanIDUint64(id trace.SpanID) uint64 {
+	return binary.BigEndian.Uint64(id[:])
+}
@@ -0,0 +1,64 @@
+package tracing
+
+import (
+	"context"
+
+	"github.com/gorilla/mux"
+	"go.opentelemetry.io/contrib/instrumentation/github.com/gorilla/mux/otelmux"
+)
+
+// bothTracer runs the Datadog and OTLP backends side by side so a single
+// request produces both a Datadog trace and an OTel/OTLP trace. The two
+// traces are not linked (they have independent trace IDs), but this is
+// enough to compare what each backend captures for the same request.
+type bothTracer struct {
+	datadog *datadogTracer
+	otlp    *otlpTracer
+}
+
+func newBothTracer() Tracer {
+	dd := newDatadogTracer().(*datadogTracer)
+	otlp := newOTLPTracer().(*otlpTracer)
+	return &bothTracer{datadog: dd, otlp: otlp}
+}
+
+// NewRouter instruments with otelmux on top of the Datadog mux
+// integration so both backends see every request.
+func (t *bothTracer) NewRouter() *mux.Router {
+	r := t.datadog.NewRouter()
+	r.Use(otelmux.Middleware("minikube-apm-nginx-sandbox"))
+	return r
+}
+
+func (t *bothTracer) StartSpanFromContext(ctx context.Context, name string) (Span, context.Context) {
+	ddSpan, ctx := t.datadog.StartSpanFromContext(ctx, name)
+	otelSpan, ctx := t.otlp.StartSpanFromContext(ctx, name)
+	return &bothSpan{ddSpan: ddSpan, otelSpan: otelSpan}, ctx
+}
+
+func (t *bothTracer) Stop() {
+	t.datadog.Stop()
+	t.otlp.Stop()
+}
+
+// bothSpan fans every tag/finish call out to both backend spans.
+type bothSpan struct {
+	ddSpan   Span
+	otelSpan Span
+}
+
+func (s *bothSpan) SetTag(key string, value interface{}) {
+	s.ddSpan.SetTag(key, value)
+	s.otelSpan.SetTag(key, value)
+}
+
+func (s *bothSpan) Finish() {
+	s.ddSpan.Finish()
+	s.otelSpan.Finish()
+}
+
+// Context reports the Datadog identifiers, since that's the primary
+// backend the sandbox's nginx log correlation was originally built for.
+func (s *bothSpan) Context() SpanContext {
+	return s.ddSpan.Context()
+}
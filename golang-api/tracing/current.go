@@ -0,0 +1,46 @@
+package tracing
+
+import (
+	"context"
+	"fmt"
+
+	"go.opentelemetry.io/otel/trace"
+	"gopkg.in/DataDog/dd-trace-go.v1/ddtrace"
+	"gopkg.in/DataDog/dd-trace-go.v1/ddtrace/tracer"
+)
+
+// SpanContextFromContext returns the identifiers of whichever span -
+// Datadog or OTel - is already active on ctx. Unlike Tracer.StartSpanFromContext,
+// this doesn't start a new span; it's for code (like logging middleware)
+// that just needs to correlate against a span already started upstream.
+func SpanContextFromContext(ctx context.Context) (SpanContext, bool) {
+	if span, ok := tracer.SpanFromContext(ctx); ok {
+		return ddSpanContext(span.Context()), true
+	}
+	if sc := trace.SpanContextFromContext(ctx); sc.IsValid() {
+		return otelSpanContext(sc), true
+	}
+	return SpanContext{}, false
+}
+
+func ddSpanContext(sc ddtrace.SpanContext) SpanContext {
+	traceID := sc.TraceID()
+	spanID := sc.SpanID()
+	return SpanContext{
+		TraceIDHex: fmt.Sprintf("%016x", traceID),
+		TraceIDDec: fmt.Sprintf("%d", traceID),
+		SpanIDHex:  fmt.Sprintf("%016x", spanID),
+		SpanIDDec:  fmt.Sprintf("%d", spanID),
+	}
+}
+
+func otelSpanContext(sc trace.SpanContext) SpanContext {
+	traceID := sc.TraceID()
+	spanID := sc.SpanID()
+	return SpanContext{
+		TraceIDHex: traceID.String(),
+		TraceIDDec: fmt.Sprintf("%d", traceIDLow64(traceID)),
+		SpanIDHex:  spanID.String(),
+		SpanIDDec:  fmt.Sprintf("%d", spanIDUint64(spanID)),
+	}
+}
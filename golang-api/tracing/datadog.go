@@ -0,0 +1,94 @@
+package tracing
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"gopkg.in/DataDog/dd-trace-go.v1/ddtrace/ext"
+	"gopkg.in/DataDog/dd-trace-go.v1/ddtrace/tracer"
+)
+
+// datadogServiceName matches the name muxtrace.NewRouter used to default
+// to, so existing dashboards/monitors built against it keep resolving.
+const datadogServiceName = "minikube-apm-nginx-sandbox"
+
+// datadogTracer is the original Datadog-only backend.
+type datadogTracer struct{}
+
+func newDatadogTracer() Tracer {
+	tracer.Start()
+	return &datadogTracer{}
+}
+
+// NewRouter returns a plain *mux.Router instrumented via a middleware
+// instead of contrib/gorilla/mux's NewRouter: that package's NewRouter
+// returns its own wrapper type (which embeds, but isn't, a *mux.Router),
+// so it can't satisfy the Tracer interface's *mux.Router return type.
+// ddMuxMiddleware traces every request the same way that wrapper did.
+func (t *datadogTracer) NewRouter() *mux.Router {
+	r := mux.NewRouter()
+	r.Use(ddMuxMiddleware)
+	return r
+}
+
+// ddMuxMiddleware starts a Datadog span per request, named after the
+// matched route's path template when one exists (mirroring
+// contrib/gorilla/mux's resource naming), so every backend's router
+// gets automatic request-level tracing the same way otelmux.Middleware
+// gives the OTLP backend one.
+func ddMuxMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		resource := r.Method + " " + r.URL.Path
+		if route := mux.CurrentRoute(r); route != nil {
+			if tmpl, err := route.GetPathTemplate(); err == nil {
+				resource = r.Method + " " + tmpl
+			}
+		}
+
+		span, ctx := tracer.StartSpanFromContext(r.Context(), "http.request",
+			tracer.ServiceName(datadogServiceName),
+			tracer.ResourceName(resource),
+			tracer.SpanType(ext.SpanTypeWeb),
+			tracer.Tag("http.method", r.Method),
+			tracer.Tag("http.url", r.URL.String()),
+		)
+		defer span.Finish()
+
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+func (t *datadogTracer) StartSpanFromContext(ctx context.Context, name string) (Span, context.Context) {
+	span, sctx := tracer.StartSpanFromContext(ctx, name)
+	return &datadogSpan{span: span}, sctx
+}
+
+func (t *datadogTracer) Stop() {
+	tracer.Stop()
+}
+
+type datadogSpan struct {
+	span tracer.Span
+}
+
+func (s *datadogSpan) SetTag(key string, value interface{}) {
+	s.span.SetTag(key, value)
+}
+
+func (s *datadogSpan) Finish() {
+	s.span.Finish()
+}
+
+func (s *datadogSpan) Context() SpanContext {
+	sc := s.span.Context()
+	traceID := sc.TraceID()
+	spanID := sc.SpanID()
+	return SpanContext{
+		TraceIDHex: fmt.Sprintf("%016x", traceID),
+		TraceIDDec: fmt.Sprintf("%d", traceID),
+		SpanIDHex:  fmt.Sprintf("%016x", spanID),
+		SpanIDDec:  fmt.Sprintf("%d", spanID),
+	}
+}
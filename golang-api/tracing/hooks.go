@@ -0,0 +1,18 @@
+package tracing
+
+import (
+	"github.com/sirupsen/logrus"
+	ddtracelogrus "gopkg.in/DataDog/dd-trace-go.v1/contrib/sirupsen/logrus"
+)
+
+// AddLogHooks attaches whichever trace/span correlation hook(s) match
+// backend to logger, so log lines can be joined to the right trace
+// regardless of which tracing backend produced it.
+func AddLogHooks(logger *logrus.Logger, backend Backend) {
+	if backend == BackendDatadog || backend == BackendBoth {
+		logger.AddHook(&ddtracelogrus.DDContextLogHook{})
+	}
+	if backend == BackendOTLP || backend == BackendBoth {
+		logger.AddHook(&OTelLogHook{})
+	}
+}
@@ -0,0 +1,37 @@
+package tracing
+
+import (
+	"fmt"
+
+	"github.com/sirupsen/logrus"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// OTelLogHook injects the active OTel trace_id/span_id (hex and decimal,
+// matching the field names the Datadog hook uses) into every log entry
+// that carries a context with a valid span context.
+type OTelLogHook struct{}
+
+func (h *OTelLogHook) Levels() []logrus.Level {
+	return logrus.AllLevels
+}
+
+func (h *OTelLogHook) Fire(entry *logrus.Entry) error {
+	if entry.Context == nil {
+		return nil
+	}
+
+	span := trace.SpanFromContext(entry.Context)
+	sc := span.SpanContext()
+	if !sc.IsValid() {
+		return nil
+	}
+
+	traceID := sc.TraceID()
+	spanID := sc.SpanID()
+	entry.Data["trace_id_hex"] = traceID.String()
+	entry.Data["trace_id_dec"] = fmt.Sprintf("%d", traceIDLow64(traceID))
+	entry.Data["span_id_hex"] = spanID.String()
+	entry.Data["span_id_dec"] = fmt.Sprintf("%d", spanIDUint64(spanID))
+	return nil
+}
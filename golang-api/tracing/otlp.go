@@ -0,0 +1,103 @@
+package tracing
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"time"
+
+	"github.com/gorilla/mux"
+	"go.opentelemetry.io/contrib/instrumentation/github.com/gorilla/mux/otelmux"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.17.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const instrumentationName = "minikube-apm-nginx-sandbox/golang-api"
+
+// otlpTracer exports spans via OTLP/gRPC to whatever backend the user
+// pointed OTEL_EXPORTER_OTLP_ENDPOINT at (Tempo, Jaeger, Honeycomb, ...).
+type otlpTracer struct {
+	provider *sdktrace.TracerProvider
+	tracer   trace.Tracer
+}
+
+func newOTLPTracer() Tracer {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	exporter, err := otlptracegrpc.New(ctx)
+	if err != nil {
+		log.Fatalf("tracing: failed to create OTLP exporter: %v", err)
+	}
+
+	serviceName := os.Getenv("OTEL_SERVICE_NAME")
+	if serviceName == "" {
+		serviceName = "minikube-apm-nginx-sandbox"
+	}
+
+	res, err := resource.New(ctx,
+		resource.WithAttributes(semconv.ServiceNameKey.String(serviceName)),
+	)
+	if err != nil {
+		log.Fatalf("tracing: failed to build OTel resource: %v", err)
+	}
+
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(res),
+	)
+	otel.SetTracerProvider(provider)
+
+	return &otlpTracer{
+		provider: provider,
+		tracer:   provider.Tracer(instrumentationName),
+	}
+}
+
+func (t *otlpTracer) NewRouter() *mux.Router {
+	r := mux.NewRouter()
+	r.Use(otelmux.Middleware("minikube-apm-nginx-sandbox"))
+	return r
+}
+
+func (t *otlpTracer) StartSpanFromContext(ctx context.Context, name string) (Span, context.Context) {
+	sctx, span := t.tracer.Start(ctx, name)
+	return &otelSpan{span: span}, sctx
+}
+
+func (t *otlpTracer) Stop() {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := t.provider.Shutdown(ctx); err != nil {
+		log.Printf("tracing: error shutting down OTel provider: %v", err)
+	}
+}
+
+type otelSpan struct {
+	span trace.Span
+}
+
+func (s *otelSpan) SetTag(key string, value interface{}) {
+	s.span.SetAttributes(attributeFor(key, value))
+}
+
+func (s *otelSpan) Finish() {
+	s.span.End()
+}
+
+func (s *otelSpan) Context() SpanContext {
+	sc := s.span.SpanContext()
+	traceID := sc.TraceID()
+	spanID := sc.SpanID()
+	return SpanContext{
+		TraceIDHex: traceID.String(),
+		TraceIDDec: fmt.Sprintf("%d", traceIDLow64(traceID)),
+		SpanIDHex:  spanID.String(),
+		SpanIDDec:  fmt.Sprintf("%d", spanIDUint64(spanID)),
+	}
+}
@@ -0,0 +1,73 @@
+// Package tracing selects and initializes the tracing backend for the
+// sandbox: Datadog APM, OpenTelemetry/OTLP, or both at once. It exists so
+// main.go and the logging package don't need to know which backend is active -
+// they just deal in the Tracer and Span interfaces below.
+package tracing
+
+import (
+	"context"
+	"os"
+
+	"github.com/gorilla/mux"
+)
+
+// Backend identifies which tracing implementation to initialize.
+type Backend string
+
+const (
+	BackendDatadog Backend = "datadog"
+	BackendOTLP    Backend = "otlp"
+	BackendBoth    Backend = "both"
+)
+
+// BackendFromEnv reads TRACER_BACKEND and falls back to BackendDatadog,
+// preserving the sandbox's original Datadog-only behavior when unset.
+func BackendFromEnv() Backend {
+	switch Backend(os.Getenv("TRACER_BACKEND")) {
+	case BackendOTLP:
+		return BackendOTLP
+	case BackendBoth:
+		return BackendBoth
+	default:
+		return BackendDatadog
+	}
+}
+
+// SpanContext carries trace/span identifiers in both hex and decimal form
+// so they can be correlated against nginx access logs, which print trace
+// IDs as decimal by convention.
+type SpanContext struct {
+	TraceIDHex string
+	TraceIDDec string
+	SpanIDHex  string
+	SpanIDDec  string
+}
+
+// Span abstracts over a Datadog or OpenTelemetry span so handlers can set
+// semantic tags without caring which backend produced the span.
+type Span interface {
+	SetTag(key string, value interface{})
+	Finish()
+	Context() SpanContext
+}
+
+// Tracer is the active tracing backend. It is responsible for starting
+// spans, instrumenting the mux router, and shutting itself down cleanly.
+type Tracer interface {
+	NewRouter() *mux.Router
+	StartSpanFromContext(ctx context.Context, name string) (Span, context.Context)
+	Stop()
+}
+
+// Init initializes the tracer selected by backend and returns it. Callers
+// should defer Stop() on the returned Tracer.
+func Init(backend Backend) Tracer {
+	switch backend {
+	case BackendOTLP:
+		return newOTLPTracer()
+	case BackendBoth:
+		return newBothTracer()
+	default:
+		return newDatadogTracer()
+	}
+}